@@ -0,0 +1,266 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultSentryFlushTimeout bounds how long Flush waits for Sentry when ctx
+// carries no deadline.
+const defaultSentryFlushTimeout = 2 * time.Second
+
+// DropPolicy controls what the async pipeline does when its buffer is full.
+type DropPolicy int32
+
+const (
+	// Block waits for room in the buffer, applying backpressure to the caller.
+	Block DropPolicy = iota
+	// DropOldest discards the oldest queued entry to make room for the new one.
+	DropOldest
+	// DropNewest discards the entry that was about to be enqueued.
+	DropNewest
+)
+
+// asyncJob is either a log entry to write, or (when barrier is non-nil) a
+// drain marker: once run reaches it, every job enqueued before it has been
+// written, and barrier is closed.
+type asyncJob struct {
+	entry   *logrus.Entry
+	level   logrus.Level
+	msg     string
+	barrier chan struct{}
+}
+
+// asyncPipeline is the background writer installed by EnableAsync.
+type asyncPipeline struct {
+	queue  chan asyncJob
+	policy atomic.Int32
+	onDrop func(Entry)
+
+	// closeMu guards closed and the transition to it, so enqueue can never
+	// send on queue concurrently with (or after) it being closed: enqueue
+	// holds the read lock for the duration of its send, and close takes the
+	// write lock before closing queue, so the two can't interleave.
+	closeMu sync.RWMutex
+	closed  bool
+
+	dropped  atomic.Uint64
+	enqueued atomic.Uint64
+	written  atomic.Uint64
+}
+
+// async holds the active *asyncPipeline, nil when EnableAsync hasn't been called.
+var async atomic.Value
+
+// EnableAsync switches the package to asynchronous logging: Debug, Info,
+// Warn and Error calls hand their entry to a buffered channel of bufferSize
+// and return immediately, while a background goroutine performs the actual
+// write (including any Sentry I/O triggered by hooks). Fatal and Panic stay
+// synchronous, draining the buffer first so nothing queued ahead of them is
+// lost. onDrop, if non-nil, is called with the dropped entry whenever
+// SetAsyncDropPolicy(DropOldest) or SetAsyncDropPolicy(DropNewest) causes one
+// to be discarded; it is never called under the default Block policy.
+func EnableAsync(bufferSize int, onDrop func(entry Entry)) {
+	if prev := currentAsync(); prev != nil {
+		prev.close()
+	}
+
+	p := &asyncPipeline{
+		queue:  make(chan asyncJob, bufferSize),
+		onDrop: onDrop,
+	}
+	p.policy.Store(int32(Block))
+
+	go p.run()
+	async.Store(p)
+}
+
+// DisableAsync drains any pending entries and returns the package to
+// synchronous logging. It is a no-op if EnableAsync hasn't been called.
+func DisableAsync() {
+	p := currentAsync()
+	if p == nil {
+		return
+	}
+	p.drain()
+	p.close()
+	async.Store((*asyncPipeline)(nil))
+}
+
+// SetAsyncDropPolicy sets the policy applied when the async buffer is full.
+// It has no effect unless EnableAsync has been called.
+func SetAsyncDropPolicy(policy DropPolicy) {
+	if p := currentAsync(); p != nil {
+		p.policy.Store(int32(policy))
+	}
+}
+
+// Stats reports counters for the async logging pipeline. It returns a zero
+// value if EnableAsync hasn't been called.
+func Stats() struct{ Dropped, Enqueued, Written uint64 } {
+	p := currentAsync()
+	if p == nil {
+		return struct{ Dropped, Enqueued, Written uint64 }{}
+	}
+	return struct{ Dropped, Enqueued, Written uint64 }{
+		Dropped:  p.dropped.Load(),
+		Enqueued: p.enqueued.Load(),
+		Written:  p.written.Load(),
+	}
+}
+
+// Flush drains the async pipeline (if EnableAsync was called) and then
+// flushes any pending Sentry events (if InitSentry was called). It returns
+// ctx.Err() if ctx is done before the drain finishes. The Sentry flush waits
+// until ctx's deadline (or defaultSentryFlushTimeout when ctx has none) and
+// returns an error if events are still pending then; it does not watch ctx
+// for cancellation.
+func Flush(ctx context.Context) error {
+	if p := currentAsync(); p != nil {
+		done := make(chan struct{})
+		go func() {
+			p.drain()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if sentryHook != nil && !sentryHook.client.Flush(sentryFlushTimeout(ctx)) {
+		return errors.New("logger: timed out flushing Sentry events")
+	}
+	return nil
+}
+
+func sentryFlushTimeout(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		if d := time.Until(deadline); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return defaultSentryFlushTimeout
+}
+
+func currentAsync() *asyncPipeline {
+	v := async.Load()
+	if v == nil {
+		return nil
+	}
+	return v.(*asyncPipeline)
+}
+
+// dispatch writes entry either synchronously, or by handing it to the async
+// pipeline when EnableAsync is active.
+func dispatch(entry *logrus.Entry, level logrus.Level, msg string) {
+	if p := currentAsync(); p != nil {
+		p.enqueue(asyncJob{entry: entry, level: level, msg: msg})
+		return
+	}
+	entry.Log(level, msg)
+}
+
+// drainAsync blocks until every job enqueued so far has been written. Called
+// before Fatal/Panic so they preserve their current synchronous semantics
+// even when async logging is enabled.
+func drainAsync() {
+	if p := currentAsync(); p != nil {
+		p.drain()
+	}
+}
+
+func (p *asyncPipeline) run() {
+	for job := range p.queue {
+		if job.barrier != nil {
+			close(job.barrier)
+			continue
+		}
+		job.entry.Log(job.level, job.msg)
+		p.written.Add(1)
+	}
+}
+
+// enqueue hands job to the background writer. It holds closeMu for its
+// duration so a concurrent EnableAsync/DisableAsync swapping this pipeline
+// out can't close queue while enqueue is sending on it; if the pipeline has
+// already been closed by the time enqueue runs, the job is silently
+// dropped instead of panicking on a closed channel.
+func (p *asyncPipeline) enqueue(job asyncJob) {
+	p.closeMu.RLock()
+	defer p.closeMu.RUnlock()
+	if p.closed {
+		return
+	}
+
+	switch DropPolicy(p.policy.Load()) {
+	case DropNewest:
+		select {
+		case p.queue <- job:
+			p.enqueued.Add(1)
+		default:
+			p.drop(job)
+		}
+	case DropOldest:
+		for {
+			select {
+			case p.queue <- job:
+				p.enqueued.Add(1)
+				return
+			default:
+				select {
+				case old := <-p.queue:
+					p.drop(old)
+				default:
+				}
+			}
+		}
+	default: // Block
+		p.queue <- job
+		p.enqueued.Add(1)
+	}
+}
+
+func (p *asyncPipeline) drop(job asyncJob) {
+	p.dropped.Add(1)
+	if p.onDrop != nil {
+		p.onDrop(newEntry(job.msg, job.entry.Data, job.entry.Context))
+	}
+}
+
+// drain blocks until every job enqueued ahead of it has been written,
+// bypassing the drop policy since it's a control message, not a log entry.
+// It is a no-op against a pipeline that's already been closed.
+func (p *asyncPipeline) drain() {
+	done := make(chan struct{})
+
+	p.closeMu.RLock()
+	if p.closed {
+		p.closeMu.RUnlock()
+		return
+	}
+	p.queue <- asyncJob{barrier: done}
+	p.closeMu.RUnlock()
+
+	<-done
+}
+
+// close marks p as torn down and closes queue, synchronized against enqueue
+// and drain via closeMu so neither can be sending on queue when it closes.
+// It is idempotent.
+func (p *asyncPipeline) close() {
+	p.closeMu.Lock()
+	defer p.closeMu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	close(p.queue)
+}
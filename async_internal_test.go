@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestJob(msg string) asyncJob {
+	return asyncJob{entry: &logrus.Entry{Logger: baseLogger, Data: logrus.Fields{}}, msg: msg}
+}
+
+func TestAsyncPipeline_DropNewest(t *testing.T) {
+	p := &asyncPipeline{queue: make(chan asyncJob, 1)}
+	p.policy.Store(int32(DropNewest))
+
+	var dropped []Entry
+	p.onDrop = func(e Entry) { dropped = append(dropped, e) }
+
+	p.enqueue(newTestJob("first"))  // fills the buffer
+	p.enqueue(newTestJob("second")) // buffer full, no consumer: dropped
+
+	if len(dropped) != 1 {
+		t.Fatalf("onDrop called %d times, want 1", len(dropped))
+	}
+	if dropped[0].Message != "second" {
+		t.Fatalf("dropped message = %q, want %q", dropped[0].Message, "second")
+	}
+	if got := p.dropped.Load(); got != 1 {
+		t.Fatalf("dropped counter = %d, want 1", got)
+	}
+}
+
+func TestAsyncPipeline_DropOldest(t *testing.T) {
+	p := &asyncPipeline{queue: make(chan asyncJob, 1)}
+	p.policy.Store(int32(DropOldest))
+
+	p.enqueue(newTestJob("first"))
+	p.enqueue(newTestJob("second")) // buffer full: "first" is evicted for "second"
+
+	if got := p.dropped.Load(); got != 1 {
+		t.Fatalf("dropped counter = %d, want 1", got)
+	}
+
+	select {
+	case job := <-p.queue:
+		if job.msg != "second" {
+			t.Fatalf("queued message = %q, want %q", job.msg, "second")
+		}
+	default:
+		t.Fatalf("expected the queue to hold the newer entry")
+	}
+}
+
+// TestAsyncPipeline_CloseDuringEnqueue races close against enqueue on a live
+// pipeline, reproducing the "send on closed channel" panic that used to
+// occur when EnableAsync/DisableAsync closed queue while dispatch was still
+// sending on it. It must pass under -race with no panic.
+func TestAsyncPipeline_CloseDuringEnqueue(t *testing.T) {
+	p := &asyncPipeline{queue: make(chan asyncJob, 1)}
+	p.policy.Store(int32(Block))
+	go p.run()
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				p.enqueue(newTestJob("x"))
+			}
+		}
+	}()
+
+	p.close()
+	close(stop)
+	wg.Wait()
+
+	// A second close must stay a no-op rather than double-closing queue.
+	p.close()
+}
@@ -0,0 +1,31 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	logger "github.com/smarter-day/logger"
+)
+
+func TestEnableAsync_WritesAndFlush(t *testing.T) {
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(os.Stderr)
+
+	logger.EnableAsync(16, nil)
+	defer logger.DisableAsync()
+	logger.Log(context.Background()).Info("async message")
+
+	if err := logger.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte("async message")) {
+		t.Fatalf("expected output to contain the logged message, got %q", buf.String())
+	}
+	if got := logger.Stats().Written; got == 0 {
+		t.Fatalf("Stats().Written = %d, want > 0", got)
+	}
+}
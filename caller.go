@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// thisPackage is always skipped when walking frames for caller info, so the
+// reported caller is whoever called into Logger, not Logger itself.
+const thisPackage = "github.com/smarter-day/logger"
+
+var callerSkipPackages = struct {
+	mu       sync.RWMutex
+	prefixes []string
+}{prefixes: []string{thisPackage}}
+
+// AddCallerSkipPackage registers an additional package import-path prefix to
+// skip over when computing caller info. Wrapper libraries (e.g. a
+// project-wide log.Info helper built on top of this package) should call
+// this with their own import path so getCallerInfo walks past them and
+// reports their caller instead.
+func AddCallerSkipPackage(prefix string) {
+	callerSkipPackages.mu.Lock()
+	defer callerSkipPackages.mu.Unlock()
+	callerSkipPackages.prefixes = append(callerSkipPackages.prefixes, prefix)
+}
+
+// isSkippedFunc reports whether funcName (as reported by runtime) belongs to
+// a registered caller-skip package.
+func isSkippedFunc(funcName string) bool {
+	callerSkipPackages.mu.RLock()
+	defer callerSkipPackages.mu.RUnlock()
+	for _, prefix := range callerSkipPackages.prefixes {
+		if strings.HasPrefix(funcName, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// getCallerInfo returns file:line and function name for the first stack
+// frame that isn't inside a registered caller-skip package, skipping an
+// additional extraSkip frames past that point.
+func getCallerInfo(extraSkip int) string {
+	const maxDepth = 32
+	pcs := make([]uintptr, maxDepth)
+	// Skip runtime.Callers itself and this function; the first frame handed
+	// back is therefore the one that called getCallerInfo.
+	n := runtime.Callers(2, pcs)
+	if n == 0 {
+		return "unknown:?"
+	}
+
+	frames := runtime.CallersFrames(pcs[:n])
+	skipped := 0
+	for {
+		frame, more := frames.Next()
+		switch {
+		case isSkippedFunc(frame.Function):
+			// Keep walking past our own package.
+		case skipped < extraSkip:
+			skipped++
+		default:
+			return fmt.Sprintf("%s:%d %s", filepath.Base(frame.File), frame.Line, filepath.Base(frame.Function))
+		}
+		if !more {
+			return "unknown:?"
+		}
+	}
+}
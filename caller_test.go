@@ -0,0 +1,96 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	logger "github.com/smarter-day/logger"
+	"github.com/smarter-day/logger/internal/callerwrap"
+)
+
+func init() {
+	logger.AddCallerSkipPackage("github.com/smarter-day/logger/internal/callerwrap")
+}
+
+// captureCallerField runs log, capturing the JSON-encoded entry it writes,
+// and returns its "caller" field.
+func captureCallerField(t *testing.T, log func()) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(os.Stderr)
+
+	log()
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unmarshal log entry: %v (raw: %s)", err, buf.String())
+	}
+	caller, _ := entry["caller"].(string)
+	return caller
+}
+
+func assertCallerLine(t *testing.T, caller string, wantLine int) {
+	t.Helper()
+	wantSuffix := fmt.Sprintf("caller_test.go:%d", wantLine)
+	if !strings.Contains(caller, wantSuffix) {
+		t.Fatalf("caller = %q, want it to contain %q", caller, wantSuffix)
+	}
+}
+
+func TestCallerInfo_DirectCall(t *testing.T) {
+	var wantLine int
+	caller := captureCallerField(t, func() {
+		_, _, wantLine, _ = runtime.Caller(0)
+		logger.Log(context.Background()).Info("direct")
+	})
+	assertCallerLine(t, caller, wantLine+1)
+}
+
+func TestCallerInfo_OneWrapperLayer(t *testing.T) {
+	var wantLine int
+	caller := captureCallerField(t, func() {
+		_, _, wantLine, _ = runtime.Caller(0)
+		callerwrap.Wrap1(context.Background(), "one wrapper layer")
+	})
+	assertCallerLine(t, caller, wantLine+1)
+}
+
+func TestCallerInfo_TwoWrapperLayers(t *testing.T) {
+	var wantLine int
+	caller := captureCallerField(t, func() {
+		_, _, wantLine, _ = runtime.Caller(0)
+		callerwrap.Wrap2(context.Background(), "two wrapper layers")
+	})
+	assertCallerLine(t, caller, wantLine+1)
+}
+
+func BenchmarkCallerInfo(b *testing.B) {
+	ctx := context.Background()
+	logger.SetOutput(io.Discard)
+	defer logger.SetOutput(os.Stderr)
+
+	b.Run("direct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			logger.Log(ctx).Info("bench")
+		}
+	})
+	b.Run("one_wrapper", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			callerwrap.Wrap1(ctx, "bench")
+		}
+	})
+	b.Run("two_wrappers", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			callerwrap.Wrap2(ctx, "bench")
+		}
+	})
+}
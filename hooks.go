@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Level mirrors logrus.Level so Hook implementations don't need to reason
+// about our choice of logging library.
+type Level = logrus.Level
+
+// Entry is the module-native representation of a log entry, passed to
+// Hook.Fire. It mirrors the fields Log/WithValues/WithError build up, plus
+// the caller info and Sentry trace/span IDs this package attaches.
+type Entry struct {
+	// Message is the log message.
+	Message string
+
+	// Fields holds the key/value pairs attached via WithValues, WithError and
+	// the level methods, including "caller", TraceIdLogKeyName /
+	// SpanIdLogKeyName when Log found a trace (see TraceID/SpanID), and
+	// OtelTraceIdLogKeyName/OtelSpanIdLogKeyName when it found two.
+	Fields map[string]interface{}
+
+	// Caller is the file:line and function name of the call site.
+	Caller string
+
+	// Context is the context.Context passed to Log, if any.
+	Context context.Context
+
+	// TraceID and SpanID are the trace/span IDs Log attached under
+	// TraceIdLogKeyName/SpanIdLogKeyName, empty if none were active. These
+	// come from a Sentry span when one is present, otherwise from the active
+	// TraceExtractor; when both are present, this holds the Sentry pair and
+	// the extractor's is available in Fields under OtelTraceIdLogKeyName/
+	// OtelSpanIdLogKeyName instead.
+	TraceID string
+	SpanID  string
+}
+
+// Hook lets callers plug custom sinks (syslog, Loki, a metrics counter, a
+// Kafka tee, ...) into the package's logger without reaching into the
+// private baseLogger. Implementations are adapted into a logrus.Hook
+// internally by RegisterHook.
+type Hook interface {
+	// Levels returns the levels this hook should fire for.
+	Levels() []Level
+
+	// Fire is called for every log entry at one of Levels.
+	Fire(entry Entry) error
+}
+
+// AddHook installs a logrus.Hook directly on the package's base logger, for
+// hooks that already speak logrus (e.g. a syslog or Loki hook from the
+// logrus ecosystem).
+func AddHook(hook logrus.Hook) {
+	baseLogger.AddHook(hook)
+}
+
+// RegisterHook adapts hook into a logrus.Hook and installs it on the
+// package's base logger.
+func RegisterHook(hook Hook) {
+	baseLogger.AddHook(&hookAdapter{hook: hook})
+}
+
+// hookAdapter lets a module-native Hook be installed as a logrus.Hook.
+type hookAdapter struct {
+	hook Hook
+}
+
+func (a *hookAdapter) Levels() []logrus.Level {
+	return a.hook.Levels()
+}
+
+func (a *hookAdapter) Fire(e *logrus.Entry) error {
+	return a.hook.Fire(newEntry(e.Message, e.Data, e.Context))
+}
+
+var _ logrus.Hook = (*hookAdapter)(nil)
+
+// newEntry builds the module-native Entry from the pieces a logrus entry
+// carries, shared by hookAdapter and the async pipeline's onDrop callback.
+func newEntry(msg string, data logrus.Fields, ctx context.Context) Entry {
+	caller, _ := data["caller"].(string)
+	traceID, _ := data[TraceIdLogKeyName].(string)
+	spanID, _ := data[SpanIdLogKeyName].(string)
+
+	return Entry{
+		Message: msg,
+		Fields:  data,
+		Caller:  caller,
+		Context: ctx,
+		TraceID: traceID,
+		SpanID:  spanID,
+	}
+}
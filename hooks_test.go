@@ -0,0 +1,56 @@
+package logger_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	logger "github.com/smarter-day/logger"
+)
+
+// recordingHook is a logger.Hook that records every Entry it's fired with.
+type recordingHook struct {
+	levels  []logger.Level
+	entries []logger.Entry
+}
+
+func (h *recordingHook) Levels() []logger.Level { return h.levels }
+
+func (h *recordingHook) Fire(e logger.Entry) error {
+	h.entries = append(h.entries, e)
+	return nil
+}
+
+// TestRegisterHook_AdapterRoundTrip verifies that a module-native Hook
+// registered via RegisterHook sees the Message, Fields, Caller and Context
+// that the underlying logrus.Entry carries. Trace/span IDs are not checked
+// here.
+func TestRegisterHook_AdapterRoundTrip(t *testing.T) {
+	logger.SetOutput(os.Stderr)
+
+	hook := &recordingHook{levels: []logger.Level{logrus.InfoLevel}}
+	logger.RegisterHook(hook)
+
+	ctx := context.Background()
+	logger.Log(ctx).WithValues("key", "value").Info("hooked message")
+
+	if len(hook.entries) != 1 {
+		t.Fatalf("hook fired %d times, want 1", len(hook.entries))
+	}
+
+	got := hook.entries[0]
+	if got.Message != "hooked message" {
+		t.Fatalf("Message = %q, want %q", got.Message, "hooked message")
+	}
+	if got.Fields["key"] != "value" {
+		t.Fatalf("Fields[%q] = %v, want %q", "key", got.Fields["key"], "value")
+	}
+	if got.Caller == "" {
+		t.Fatalf("Caller is empty, want a file:line/function")
+	}
+	if got.Context != ctx {
+		t.Fatalf("Context = %v, want the context passed to Log", got.Context)
+	}
+}
@@ -0,0 +1,19 @@
+// Package callerwrap exercises logger.Log through one and two layers of
+// wrapping, for caller-info tests in the logger package's own test suite.
+package callerwrap
+
+import (
+	"context"
+
+	"github.com/smarter-day/logger"
+)
+
+// Wrap1 logs msg through a single wrapper layer.
+func Wrap1(ctx context.Context, msg string) {
+	logger.Log(ctx).Info(msg)
+}
+
+// Wrap2 logs msg through two wrapper layers.
+func Wrap2(ctx context.Context, msg string) {
+	Wrap1(ctx, msg)
+}
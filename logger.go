@@ -32,4 +32,9 @@ type ILogger interface {
 
 	// WithError returns a new Logger with additional error.
 	WithError(err error) ILogger
+
+	// WithCallerSkip returns a new Logger that skips n additional stack
+	// frames when computing caller info, for wrapper layers that don't
+	// register their package via AddCallerSkipPackage.
+	WithCallerSkip(n int) ILogger
 }
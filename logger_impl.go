@@ -2,12 +2,9 @@ package logger
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/getsentry/sentry-go"
 	"github.com/sirupsen/logrus"
-	"path/filepath"
-	"runtime"
 	"time"
 )
 
@@ -16,6 +13,13 @@ const (
 	ErrorKey          = "error"
 	SpanIdLogKeyName  = "spanID"
 	TraceIdLogKeyName = "traceID"
+
+	// OtelTraceIdLogKeyName and OtelSpanIdLogKeyName are used instead of
+	// TraceIdLogKeyName/SpanIdLogKeyName when both a Sentry span and a
+	// trace extracted via the active TraceExtractor are present on the same
+	// context, so neither is lost.
+	OtelTraceIdLogKeyName = "otelTraceID"
+	OtelSpanIdLogKeyName  = "otelSpanID"
 )
 
 // baseLogger is our global base logger configuration.
@@ -33,32 +37,60 @@ func init() {
 	baseLogger = log
 }
 
-// Log returns a logger instance potentially enriched with Sentry Trace and Span IDs
-// extracted from the context if a Sentry span is active.
+// Log returns a logger instance potentially enriched with Sentry and/or
+// OpenTelemetry trace/span IDs extracted from the context.
 func Log(ctx context.Context) ILogger {
 	entry := logrus.NewEntry(baseLogger) // Start with a base entry for each call
 	fields := logrus.Fields{}
 
 	// Attempt to extract Sentry span context
+	var sentryTraceID, sentrySpanID string
+	var haveSentry bool
 	if ctx != nil {
 		if span := sentry.SpanFromContext(getSentryContext(ctx)); span != nil {
 			traceID := span.TraceID.String()
 			spanID := span.SpanID.String()
 			if !isIdNull(traceID) && !isIdNull(spanID) {
-				fields[TraceIdLogKeyName] = traceID
-				fields[SpanIdLogKeyName] = spanID
+				sentryTraceID, sentrySpanID = traceID, spanID
+				haveSentry = true
 			}
 		}
 	}
 
-	// Return logger with potentially added Sentry fields
-	return &Logger{Entry: entry.WithFields(fields), Context: ctx}
+	// Attempt to extract a trace via the active TraceExtractor (OpenTelemetry
+	// by default, when built with -tags otel).
+	var extractedTraceID, extractedSpanID string
+	var haveExtracted bool
+	if ctx != nil {
+		extractedTraceID, extractedSpanID, haveExtracted = currentTraceExtractor()(ctx)
+	}
+
+	switch {
+	case haveSentry && haveExtracted:
+		fields[TraceIdLogKeyName] = sentryTraceID
+		fields[SpanIdLogKeyName] = sentrySpanID
+		fields[OtelTraceIdLogKeyName] = extractedTraceID
+		fields[OtelSpanIdLogKeyName] = extractedSpanID
+	case haveSentry:
+		fields[TraceIdLogKeyName] = sentryTraceID
+		fields[SpanIdLogKeyName] = sentrySpanID
+	case haveExtracted:
+		fields[TraceIdLogKeyName] = extractedTraceID
+		fields[SpanIdLogKeyName] = extractedSpanID
+	}
+
+	// Return logger with potentially added trace fields
+	return &Logger{Entry: entry.WithFields(fields).WithContext(ctx), Context: ctx}
 }
 
 // Logger is a Logrus-based ILogger.
 type Logger struct {
 	Entry   *logrus.Entry
 	Context context.Context
+
+	// callerSkip is the number of extra frames to skip, beyond registered
+	// caller-skip packages, when computing caller info. See WithCallerSkip.
+	callerSkip int
 }
 
 // SetLevel sets the log level for the underlying logger instance.
@@ -67,66 +99,83 @@ func (l *Logger) SetLevel(level logrus.Level) ILogger {
 	return l
 }
 
-// Debug logs a message at debug level with optional fields.
+// Debug logs a message at debug level with optional fields. When async
+// logging is enabled via EnableAsync, the write happens on the background
+// goroutine.
 func (l *Logger) Debug(msg string, keysAndValues ...interface{}) {
-	l.Entry.WithFields(l.convertToFields(keysAndValues...)).
-		WithField("caller", getCallerInfo()).
-		Debug(msg)
+	entry := l.Entry.WithFields(l.convertToFields(keysAndValues...)).
+		WithField("caller", getCallerInfo(l.callerSkip))
+	dispatch(entry, logrus.DebugLevel, msg)
 }
 
-// Info logs a message at info level with optional fields.
+// Info logs a message at info level with optional fields. When async logging
+// is enabled via EnableAsync, the write happens on the background goroutine.
 func (l *Logger) Info(msg string, keysAndValues ...interface{}) {
-	l.Entry.WithFields(l.convertToFields(keysAndValues...)).
-		WithField("caller", getCallerInfo()).
-		Info(msg)
+	entry := l.Entry.WithFields(l.convertToFields(keysAndValues...)).
+		WithField("caller", getCallerInfo(l.callerSkip))
+	dispatch(entry, logrus.InfoLevel, msg)
 }
 
-// Warn logs a message at warning level with optional fields.
+// Warn logs a message at warning level with optional fields. When async
+// logging is enabled via EnableAsync, the write happens on the background
+// goroutine.
 func (l *Logger) Warn(msg string, keysAndValues ...interface{}) {
-	l.Entry.WithFields(l.convertToFields(keysAndValues...)).
-		WithField("caller", getCallerInfo()).
-		Warn(msg)
+	entry := l.Entry.WithFields(l.convertToFields(keysAndValues...)).
+		WithField("caller", getCallerInfo(l.callerSkip))
+	dispatch(entry, logrus.WarnLevel, msg)
 }
 
-// Error logs a message at error level with optional fields.
-// Sentry capture should happen explicitly where the error is handled using the context-aware hub.
+// Error logs a message at error level with optional fields. When InitSentry
+// has been called, the Sentry hook forwards this entry (and any error set via
+// WithError) as an exception event. When async logging is enabled via
+// EnableAsync, both the write and the Sentry hook run on the background
+// goroutine, keeping the Sentry I/O off the caller's hot path.
 func (l *Logger) Error(msg string, keysAndValues ...interface{}) {
-	l.captureErrors(msg)
 	fields := l.convertToFields(keysAndValues...)
-	l.Entry.WithFields(fields).
-		WithField("caller", getCallerInfo()).
-		Error(msg)
+	entry := l.Entry.WithFields(fields).
+		WithField("caller", getCallerInfo(l.callerSkip))
+	dispatch(entry, logrus.ErrorLevel, msg)
 }
 
-// Fatal logs a message at fatal level, then exits.
+// Fatal logs a message at fatal level, then exits. It drains any pending
+// async log entries first, so async mode doesn't lose or reorder output
+// relative to the fatal message.
 func (l *Logger) Fatal(msg string, keysAndValues ...interface{}) {
-	l.captureErrors(msg)
-
 	fields := l.convertToFields(keysAndValues...)
-	l.Entry.WithFields(fields).
-		WithField("caller", getCallerInfo()).
-		Fatal(msg)
+	entry := l.Entry.WithFields(fields).
+		WithField("caller", getCallerInfo(l.callerSkip))
+	drainAsync()
+	entry.Fatal(msg)
 }
 
-// Panic logs a message at panic level, then panics.
+// Panic logs a message at panic level, then panics. It drains any pending
+// async log entries first, so async mode doesn't lose or reorder output
+// relative to the panic message.
 func (l *Logger) Panic(msg string, keysAndValues ...interface{}) {
-	l.captureErrors(msg)
-
 	fields := l.convertToFields(keysAndValues...)
-	l.Entry.WithFields(fields).
-		WithField("caller", getCallerInfo()).
-		Panic(msg) // Note: Panic triggers a panic
+	entry := l.Entry.WithFields(fields).
+		WithField("caller", getCallerInfo(l.callerSkip))
+	drainAsync()
+	entry.Panic(msg) // Note: Panic triggers a panic
 }
 
 // WithValues returns a new ILogger with additional fields added to the entry.
 func (l *Logger) WithValues(keysAndValues ...interface{}) ILogger {
 	fields := l.convertToFields(keysAndValues...)
-	return &Logger{Entry: l.Entry.WithFields(fields), Context: l.Context}
+	return &Logger{Entry: l.Entry.WithFields(fields), Context: l.Context, callerSkip: l.callerSkip}
 }
 
 // WithError returns a new ILogger that includes the given error in the log context.
 func (l *Logger) WithError(err error) ILogger {
-	return &Logger{Entry: l.Entry.WithField(ErrorKey, err), Context: l.Context}
+	return &Logger{Entry: l.Entry.WithField(ErrorKey, err), Context: l.Context, callerSkip: l.callerSkip}
+}
+
+// WithCallerSkip returns a new ILogger that skips n additional stack frames,
+// beyond any registered via AddCallerSkipPackage, when computing caller info.
+// Wrapper libraries that can't or don't want to register their whole package
+// can use this instead.
+func (l *Logger) WithCallerSkip(n int) ILogger {
+	return &Logger{Entry: l.Entry, Context: l.Context, callerSkip: l.callerSkip + n}
 }
 
 // convertToFields turns key-value pairs into Logrus fields.
@@ -153,46 +202,6 @@ func (l *Logger) convertToFields(keysAndValues ...interface{}) logrus.Fields {
 	return fields
 }
 
-func (l *Logger) captureErrors(msg string) {
-	if l.Context == nil {
-		return
-	}
-
-	var hub *sentry.Hub
-	if h, ok := l.Context.Value(sentry.HubContextKey).(*sentry.Hub); ok {
-		hub = h
-	} else {
-		return
-	}
-
-	if msg != "" {
-		hub.CaptureException(errors.New(msg))
-	}
-
-	// Capture errors in fields
-	fields := l.Entry.Data
-	for _, value := range fields {
-		if err, isError := value.(error); isError {
-			hub.CaptureException(err)
-		}
-	}
-}
-
-// getCallerInfo returns file:line and function name for the calling code.
-func getCallerInfo() string {
-	const skipFrames = 3
-	pc, file, line, ok := runtime.Caller(skipFrames)
-	if !ok {
-		return "unknown:?"
-	}
-	fn := runtime.FuncForPC(pc)
-	funcName := "unknown"
-	if fn != nil {
-		funcName = filepath.Base(fn.Name())
-	}
-	return fmt.Sprintf("%s:%d %s", filepath.Base(file), line, funcName)
-}
-
 // isIdNull checks if a trace or span ID string is effectively null (empty or all zeros).
 func isIdNull(id string) bool {
 	if len(id) == 0 {
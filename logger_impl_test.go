@@ -0,0 +1,87 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+
+	logger "github.com/smarter-day/logger"
+)
+
+// captureLogFields runs log, capturing the JSON-encoded entry it writes, and
+// returns its fields.
+func captureLogFields(t *testing.T, log func()) map[string]interface{} {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(os.Stderr)
+
+	log()
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unmarshal log entry: %v (raw: %s)", err, buf.String())
+	}
+	return entry
+}
+
+// TestLog_SentryAndExtractorBothPresent covers the "both present" branch of
+// Log's precedence switch: when a Sentry span and a trace from the active
+// TraceExtractor are both on the context, the Sentry pair goes under
+// TraceIdLogKeyName/SpanIdLogKeyName as usual, and the extractor's pair is
+// kept alongside under OtelTraceIdLogKeyName/OtelSpanIdLogKeyName instead of
+// being dropped.
+func TestLog_SentryAndExtractorBothPresent(t *testing.T) {
+	logger.SetTraceExtractor(func(ctx context.Context) (string, string, bool) {
+		return "extracted-trace-id", "extracted-span-id", true
+	})
+	defer logger.SetTraceExtractor(func(context.Context) (string, string, bool) { return "", "", false })
+
+	span := sentry.StartSpan(context.Background(), "test-op")
+	defer span.Finish()
+
+	entry := captureLogFields(t, func() {
+		logger.Log(span.Context()).Info("both sources present")
+	})
+
+	if got, want := entry[logger.TraceIdLogKeyName], span.TraceID.String(); got != want {
+		t.Fatalf("%s = %v, want Sentry trace ID %q", logger.TraceIdLogKeyName, got, want)
+	}
+	if got, want := entry[logger.SpanIdLogKeyName], span.SpanID.String(); got != want {
+		t.Fatalf("%s = %v, want Sentry span ID %q", logger.SpanIdLogKeyName, got, want)
+	}
+	if got, want := entry[logger.OtelTraceIdLogKeyName], "extracted-trace-id"; got != want {
+		t.Fatalf("%s = %v, want %q", logger.OtelTraceIdLogKeyName, got, want)
+	}
+	if got, want := entry[logger.OtelSpanIdLogKeyName], "extracted-span-id"; got != want {
+		t.Fatalf("%s = %v, want %q", logger.OtelSpanIdLogKeyName, got, want)
+	}
+}
+
+// TestLog_ExtractorOnly covers the "extractor only" branch: with no Sentry
+// span active, the extractor's pair goes under the plain (non-otel) keys.
+func TestLog_ExtractorOnly(t *testing.T) {
+	logger.SetTraceExtractor(func(ctx context.Context) (string, string, bool) {
+		return "extracted-trace-id", "extracted-span-id", true
+	})
+	defer logger.SetTraceExtractor(func(context.Context) (string, string, bool) { return "", "", false })
+
+	entry := captureLogFields(t, func() {
+		logger.Log(context.Background()).Info("extractor only")
+	})
+
+	if got, want := entry[logger.TraceIdLogKeyName], "extracted-trace-id"; got != want {
+		t.Fatalf("%s = %v, want %q", logger.TraceIdLogKeyName, got, want)
+	}
+	if got, want := entry[logger.SpanIdLogKeyName], "extracted-span-id"; got != want {
+		t.Fatalf("%s = %v, want %q", logger.SpanIdLogKeyName, got, want)
+	}
+	if _, ok := entry[logger.OtelTraceIdLogKeyName]; ok {
+		t.Fatalf("%s should be absent when no Sentry span is active", logger.OtelTraceIdLogKeyName)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build otel
+
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// extractOtelTrace extracts the W3C trace/span IDs from an active
+// OpenTelemetry span in ctx, if any. Built only with -tags otel, so
+// consumers that only use Sentry don't pull in the OpenTelemetry API.
+func extractOtelTrace(ctx context.Context) (traceID, spanID string, ok bool) {
+	sc := trace.SpanFromContext(ctx).SpanContext()
+	if !sc.IsValid() {
+		return "", "", false
+	}
+	return sc.TraceID().String(), sc.SpanID().String(), true
+}
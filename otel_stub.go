@@ -0,0 +1,13 @@
+//go:build !otel
+
+package logger
+
+import "context"
+
+// extractOtelTrace is a no-op without the otel build tag, so the package
+// doesn't depend on go.opentelemetry.io/otel unless a consumer opts in.
+// Build with -tags otel to enable OpenTelemetry span detection, or call
+// SetTraceExtractor to supply a custom extractor instead.
+func extractOtelTrace(ctx context.Context) (traceID, spanID string, ok bool) {
+	return "", "", false
+}
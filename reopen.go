@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// SetOutput sets the destination for log output on the package's base logger.
+// Pass a *ReopenableFile (see NewReopenableFile) to support log rotation via
+// external tools like logrotate.
+func SetOutput(w io.Writer) {
+	baseLogger.SetOutput(w)
+}
+
+// ReopenableFile is an io.WriteCloser backed by a file on disk that
+// transparently reopens itself on SIGHUP, so external tools like logrotate
+// can rotate the file without the process dropping log entries or needing a
+// restart. This mirrors the client9/reopen pattern used by GitLab LabKit.
+type ReopenableFile struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+
+	signals chan os.Signal
+	done    chan struct{}
+}
+
+// NewReopenableFile opens path in append mode (creating it if necessary) and
+// starts watching for SIGHUP to reopen it.
+func NewReopenableFile(path string) (io.WriteCloser, error) {
+	file, err := openAppend(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rf := &ReopenableFile{
+		path:    path,
+		file:    file,
+		signals: make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+	}
+
+	signal.Notify(rf.signals, syscall.SIGHUP)
+	go rf.watch()
+
+	return rf, nil
+}
+
+func openAppend(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+}
+
+func (rf *ReopenableFile) watch() {
+	for {
+		select {
+		case <-rf.signals:
+			_ = rf.reopen()
+		case <-rf.done:
+			return
+		}
+	}
+}
+
+// reopen closes the current file and opens path anew, so writers pick up a
+// file moved aside by logrotate. Writers block on mu for the brief swap.
+func (rf *ReopenableFile) reopen() error {
+	file, err := openAppend(rf.path)
+	if err != nil {
+		return err
+	}
+
+	rf.mu.Lock()
+	old := rf.file
+	rf.file = file
+	rf.mu.Unlock()
+
+	return old.Close()
+}
+
+// Write writes p to the underlying file.
+func (rf *ReopenableFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return rf.file.Write(p)
+}
+
+// Close stops watching for SIGHUP and closes the underlying file.
+func (rf *ReopenableFile) Close() error {
+	signal.Stop(rf.signals)
+	close(rf.done)
+
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return rf.file.Close()
+}
+
+var _ io.WriteCloser = (*ReopenableFile)(nil)
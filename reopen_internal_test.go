@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestReopenableFile_ReopenDuringWrite races reopen (as triggered by SIGHUP in
+// production) against concurrent Write calls, simulating logrotate moving the
+// file aside mid-stream. It must pass under -race, and every byte reported as
+// written by Write must land in either the rotated-away file or the final
+// one, none lost or corrupted.
+func TestReopenableFile_ReopenDuringWrite(t *testing.T) {
+	const (
+		writers        = 4
+		writesPerRound = 200
+		rounds         = 3
+	)
+	line := []byte("0123456789\n")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := NewReopenableFile(path)
+	if err != nil {
+		t.Fatalf("NewReopenableFile: %v", err)
+	}
+	rf := w.(*ReopenableFile)
+	defer rf.Close()
+
+	var wantBytes atomic.Int64
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				n, err := rf.Write(line)
+				if err != nil {
+					t.Errorf("Write: %v", err)
+					return
+				}
+				wantBytes.Add(int64(n))
+			}
+		}()
+	}
+
+	for round := 0; round < rounds; round++ {
+		if err := os.Rename(path, path+"."+string(rune('0'+round))); err != nil {
+			t.Fatalf("Rename: %v", err)
+		}
+		if err := rf.reopen(); err != nil {
+			t.Fatalf("reopen: %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	gotBytes := int64(0)
+	matches, err := filepath.Glob(path + "*")
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	for _, m := range matches {
+		info, err := os.Stat(m)
+		if err != nil {
+			t.Fatalf("Stat(%q): %v", m, err)
+		}
+		gotBytes += info.Size()
+	}
+
+	if gotBytes != wantBytes.Load() {
+		t.Fatalf("total bytes on disk = %d, want %d (some writes lost or duplicated)", gotBytes, wantBytes.Load())
+	}
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"sync"
+
+	"github.com/getsentry/sentry-go"
+	sentrylogrus "github.com/getsentry/sentry-go/logrus"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultSentryLevels are the levels forwarded to Sentry when InitSentry is
+// called without an explicit level list.
+var defaultSentryLevels = []logrus.Level{
+	logrus.ErrorLevel,
+	logrus.FatalLevel,
+	logrus.PanicLevel,
+}
+
+// sentryHook is the hook installed on baseLogger by the most recent
+// InitSentry call, tracked so a later call can remove it before installing
+// its replacement instead of stacking another hook on top of it.
+var sentryHook *contextHubHook
+
+// InitSentry installs github.com/getsentry/sentry-go/logrus as a hook on the
+// package's base logger, so that log entries at the given levels (Error,
+// Fatal and Panic by default) are forwarded to Sentry with proper
+// stacktraces, level mapping and exception chains. Calling it again (e.g. on
+// a config reload) replaces the previously installed hook rather than
+// adding a second one.
+func InitSentry(options sentry.ClientOptions, levels []logrus.Level) error {
+	if len(levels) == 0 {
+		levels = defaultSentryLevels
+	}
+
+	client, err := sentry.NewClient(options)
+	if err != nil {
+		return err
+	}
+	client.SetSDKIdentifier("sentry.go.logrus")
+
+	hook := sentrylogrus.NewFromClient(levels, client)
+	removeSentryHook()
+	sentryHook = &contextHubHook{
+		hook:       hook,
+		client:     client,
+		defaultHub: sentry.NewHub(client, sentry.NewScope()),
+	}
+	baseLogger.AddHook(sentryHook)
+	return nil
+}
+
+// removeSentryHook unregisters the hook installed by a previous InitSentry
+// call from baseLogger, if any, so a subsequent InitSentry call replaces it
+// instead of entries being forwarded to Sentry once per past call.
+func removeSentryHook() {
+	if sentryHook == nil {
+		return
+	}
+
+	old := baseLogger.ReplaceHooks(logrus.LevelHooks{})
+	kept := logrus.LevelHooks{}
+	for level, hooks := range old {
+		for _, h := range hooks {
+			if h == logrus.Hook(sentryHook) {
+				continue
+			}
+			kept[level] = append(kept[level], h)
+		}
+	}
+	baseLogger.ReplaceHooks(kept)
+}
+
+// contextHubHook adapts sentrylogrus.Hook so that entries carrying a Sentry
+// Hub bound to their context (via sentry.HubContextKey) are reported through
+// that hub instead of the hook's default one, keeping events scoped to the
+// request/job that produced them. Entries with no bound hub fall back to
+// defaultHub, rather than whatever hub the previous Fire call happened to
+// set, so an event never gets misattributed to an unrelated request.
+type contextHubHook struct {
+	hook       *sentrylogrus.Hook
+	client     *sentry.Client
+	defaultHub *sentry.Hub
+	mu         sync.Mutex
+}
+
+// Levels returns the levels forwarded to Sentry.
+func (h *contextHubHook) Levels() []logrus.Level {
+	return h.hook.Levels()
+}
+
+// Fire sends entry to Sentry, using the hub bound to entry.Context when
+// present and falling back to defaultHub otherwise.
+func (h *contextHubHook) Fire(entry *logrus.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hub := h.defaultHub
+	if entry.Context != nil {
+		if ctxHub, ok := entry.Context.Value(sentry.HubContextKey).(*sentry.Hub); ok && ctxHub != nil {
+			hub = ctxHub
+		}
+	}
+	h.hook.SetHubProvider(func() *sentry.Hub { return hub })
+	return h.hook.Fire(entry)
+}
+
+var _ logrus.Hook = (*contextHubHook)(nil)
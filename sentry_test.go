@@ -0,0 +1,89 @@
+package logger_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+
+	logger "github.com/smarter-day/logger"
+)
+
+// fakeTransport records every event it's asked to send, so tests can inspect
+// which hub's scope (tags, in particular) ended up attached to each one.
+type fakeTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *fakeTransport) Configure(sentry.ClientOptions) {}
+
+func (t *fakeTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func (t *fakeTransport) Flush(time.Duration) bool { return true }
+
+func (t *fakeTransport) Close() {}
+
+// TestInitSentry_HubScoping verifies that an entry logged through a
+// request-scoped hub gets that hub's tags, and that a subsequent entry with
+// no hub bound to its context falls back to the default hub instead of
+// sticking with whatever hub the previous Fire call used.
+func TestInitSentry_HubScoping(t *testing.T) {
+	transport := &fakeTransport{}
+	if err := logger.InitSentry(sentry.ClientOptions{Transport: transport}, nil); err != nil {
+		t.Fatalf("InitSentry: %v", err)
+	}
+
+	client, err := sentry.NewClient(sentry.ClientOptions{Transport: transport})
+	if err != nil {
+		t.Fatalf("sentry.NewClient: %v", err)
+	}
+	requestHub := sentry.NewHub(client, sentry.NewScope())
+	requestHub.Scope().SetTag("hub", "request")
+
+	requestCtx := sentry.SetHubOnContext(context.Background(), requestHub)
+	logger.Log(requestCtx).Error("scoped to the request hub")
+
+	logger.Log(context.Background()).Error("scoped to the default hub")
+
+	if got := len(transport.events); got != 2 {
+		t.Fatalf("got %d events, want 2", got)
+	}
+	if got := transport.events[0].Tags["hub"]; got != "request" {
+		t.Fatalf("event 1 hub tag = %q, want %q", got, "request")
+	}
+	if got, ok := transport.events[1].Tags["hub"]; ok {
+		t.Fatalf("event 2 carried the request hub's tag %q, want the default hub's (untagged)", got)
+	}
+}
+
+// TestInitSentry_ReInitReplacesHook verifies that calling InitSentry a
+// second time replaces the previously installed hook instead of stacking a
+// second one on top of it, which used to forward every entry to Sentry once
+// per past InitSentry call.
+func TestInitSentry_ReInitReplacesHook(t *testing.T) {
+	first := &fakeTransport{}
+	if err := logger.InitSentry(sentry.ClientOptions{Transport: first}, nil); err != nil {
+		t.Fatalf("InitSentry (first): %v", err)
+	}
+
+	second := &fakeTransport{}
+	if err := logger.InitSentry(sentry.ClientOptions{Transport: second}, nil); err != nil {
+		t.Fatalf("InitSentry (second): %v", err)
+	}
+
+	logger.Log(context.Background()).Error("after re-init")
+
+	if got := len(first.events); got != 0 {
+		t.Fatalf("first transport got %d events, want 0 (hook should have been replaced)", got)
+	}
+	if got := len(second.events); got != 1 {
+		t.Fatalf("second transport got %d events, want 1", got)
+	}
+}
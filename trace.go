@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// TraceExtractor extracts a trace/span ID pair from a context, for
+// propagators this package doesn't know about natively (Jaeger, Datadog,
+// gRPC metadata, ...).
+type TraceExtractor func(ctx context.Context) (traceID, spanID string, ok bool)
+
+// traceExtractor defaults to extractOtelTrace, which is a no-op unless built
+// with -tags otel. SetTraceExtractor overrides it.
+var traceExtractor atomic.Value
+
+func init() {
+	traceExtractor.Store(TraceExtractor(extractOtelTrace))
+}
+
+// SetTraceExtractor overrides the function Log uses to enrich entries with
+// traceID/spanID fields when no Sentry span is active on the context (or
+// under otelTraceID/otelSpanID when one is). Register this to support a
+// tracing system other than OpenTelemetry without a hard dependency on it.
+func SetTraceExtractor(extractor TraceExtractor) {
+	traceExtractor.Store(extractor)
+}
+
+func currentTraceExtractor() TraceExtractor {
+	return traceExtractor.Load().(TraceExtractor)
+}